@@ -0,0 +1,9 @@
+package main
+
+import "github.com/urfave/cli"
+
+// commands is the full set of themekit subcommands.
+var commands = []cli.Command{
+	configCommand,
+	ignoreCommand,
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rk-ShopDev/themekit/kit"
+	"github.com/urfave/cli"
+)
+
+// configCommand groups subcommands that inspect or manage themekit's
+// configuration file.
+var configCommand = cli.Command{
+	Name:  "config",
+	Usage: "inspect and manage themekit configuration",
+	Subcommands: []cli.Command{
+		configDoctorCommand,
+		configEncryptCommand,
+		configDecryptCommand,
+	},
+}
+
+var configDoctorCommand = cli.Command{
+	Name:  "doctor",
+	Usage: "run live checks against the current configuration and report problems",
+	Action: func(c *cli.Context) error {
+		conf, err := kit.NewConfiguration()
+		if err != nil {
+			return err
+		}
+
+		for _, diagnostic := range conf.Doctor(context.Background()) {
+			fmt.Printf("[%s] %s: %s\n", diagnostic.Severity, diagnostic.Check, diagnostic.Message)
+		}
+		return nil
+	},
+}
+
+var configEncryptCommand = cli.Command{
+	Name:      "encrypt",
+	Usage:     "encrypt a plaintext value with the vault passphrase",
+	ArgsUsage: "<plaintext>",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.NewExitError("usage: themekit config encrypt <plaintext>", 1)
+		}
+
+		encrypted, err := kit.EncryptValue(c.Args().First())
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(encrypted)
+		return nil
+	},
+}
+
+var configDecryptCommand = cli.Command{
+	Name:      "decrypt",
+	Usage:     `decrypt a value produced by "config encrypt"`,
+	ArgsUsage: "<encrypted>",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.NewExitError("usage: themekit config decrypt <encrypted>", 1)
+		}
+
+		plaintext, err := kit.DecryptValue(c.Args().First())
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(plaintext)
+		return nil
+	},
+}
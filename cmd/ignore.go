@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rk-ShopDev/themekit/kit"
+	"github.com/urfave/cli"
+)
+
+// ignoreCommand groups subcommands for inspecting the Ignorer's rule set.
+var ignoreCommand = cli.Command{
+	Name:  "ignore",
+	Usage: "inspect which ignore rule applies to a path",
+	Subcommands: []cli.Command{
+		ignoreTestCommand,
+	},
+}
+
+var ignoreTestCommand = cli.Command{
+	Name:      "test",
+	Usage:     "print which ignore rule, if any, matches a path",
+	ArgsUsage: "<path>",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return cli.NewExitError("usage: themekit ignore test <path>", 1)
+		}
+
+		conf, err := kit.NewConfiguration()
+		if err != nil {
+			return err
+		}
+
+		result := conf.Ignorer().Trace(c.Args().First())
+		if result.Rule == "" {
+			fmt.Println("no rule matched")
+			return nil
+		}
+
+		verb := "included"
+		if result.Ignored {
+			verb = "ignored"
+		}
+		fmt.Printf("%s by rule %q\n", verb, result.Rule)
+		return nil
+	},
+}
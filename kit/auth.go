@@ -0,0 +1,193 @@
+package kit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authenticator attaches credentials to an outgoing API request. Configuration
+// selects one via Authenticator() based on Auth.Mode.
+type Authenticator interface {
+	Apply(req *http.Request) error
+}
+
+// Authenticator builds the Authenticator selected by conf.Auth.Mode,
+// defaulting to the private-app password scheme for backwards compatibility.
+// The built Authenticator is cached on conf so that stateful schemes like
+// OAuth2Auth keep their token cache across repeated calls instead of
+// refreshing on every request.
+//
+// compile() calls Authenticator once, while newConfig is still only visible
+// to the compiling goroutine, so that by the time a compiled Configuration
+// is shared across the goroutines Concurrency fans requests out across, the
+// cache is already populated and every later call is a plain read. Calling
+// Authenticator concurrently on a *Configuration that hasn't gone through
+// compile() yet (and so may still have a nil cache) is not safe.
+func (conf *Configuration) Authenticator() Authenticator {
+	if conf.authenticator != nil {
+		return conf.authenticator
+	}
+
+	switch strings.ToLower(conf.Auth.Mode) {
+	case "oauth2":
+		conf.authenticator = &OAuth2Auth{
+			ClientID:     conf.Auth.ClientID,
+			ClientSecret: conf.Auth.ClientSecret,
+			RefreshToken: conf.Auth.RefreshToken,
+			TokenURL:     conf.Auth.TokenURL,
+			Domain:       conf.Domain,
+		}
+	case "jwt":
+		conf.authenticator = JWTAuth{
+			Secret:    conf.Auth.Secret,
+			Subject:   conf.Auth.Subject,
+			ExpiresIn: conf.Auth.ExpiresIn,
+		}
+	default:
+		conf.authenticator = PasswordAuth{Password: conf.Password.String()}
+	}
+
+	return conf.authenticator
+}
+
+// PasswordAuth authenticates requests with a Shopify private-app password,
+// the historical and still-default authentication scheme.
+type PasswordAuth struct {
+	Password string
+}
+
+// Apply sets the X-Shopify-Access-Token header from the configured password.
+func (auth PasswordAuth) Apply(req *http.Request) error {
+	req.Header.Set("X-Shopify-Access-Token", auth.Password)
+	return nil
+}
+
+// OAuth2Auth authenticates requests with an OAuth2 bearer token, fetching
+// and caching an access token via the refresh_token grant against TokenURL,
+// or Shopify's standard oauth access_token endpoint for Domain if unset.
+type OAuth2Auth struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	TokenURL     string
+	Domain       string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// Apply ensures a valid access token is cached, refreshing it first if
+// necessary, then sets it as a Bearer Authorization header.
+func (auth *OAuth2Auth) Apply(req *http.Request) error {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	if auth.accessToken == "" || time.Now().After(auth.expiresAt) {
+		if err := auth.refresh(); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+auth.accessToken)
+	return nil
+}
+
+func (auth *OAuth2Auth) refresh() error {
+	tokenURL := auth.TokenURL
+	if tokenURL == "" {
+		tokenURL = fmt.Sprintf("https://%s/admin/oauth/access_token", auth.Domain)
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {auth.ClientID},
+		"client_secret": {auth.ClientSecret},
+		"refresh_token": {auth.RefreshToken},
+	}
+
+	resp, err := http.PostForm(tokenURL, form)
+	if err != nil {
+		return fmt.Errorf("could not refresh oauth2 token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not refresh oauth2 token: server returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("could not decode oauth2 token response: %v", err)
+	}
+
+	auth.accessToken = body.AccessToken
+	if body.ExpiresIn > 0 {
+		auth.expiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	} else {
+		auth.expiresAt = time.Now().Add(time.Hour)
+	}
+	return nil
+}
+
+// JWTAuth authenticates requests with an HMAC-SHA256 signed JWT, for App
+// Bridge and custom-app installs that verify a shared secret instead of a
+// static access token.
+type JWTAuth struct {
+	Secret    string
+	Subject   string
+	ExpiresIn time.Duration
+}
+
+// Apply mints a fresh signed JWT and sets it as a Bearer Authorization header.
+func (auth JWTAuth) Apply(req *http.Request) error {
+	token, err := auth.sign()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (auth JWTAuth) sign() (string, error) {
+	expiresIn := auth.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = 5 * time.Minute
+	}
+
+	now := time.Now()
+	header := map[string]string{"alg": "HS256", "typ": "JWT"}
+	claims := map[string]interface{}{
+		"sub": auth.Subject,
+		"iat": now.Unix(),
+		"exp": now.Add(expiresIn).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	segment := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	mac := hmac.New(sha256.New, []byte(auth.Secret))
+	mac.Write([]byte(segment))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return segment + "." + signature, nil
+}
@@ -0,0 +1,164 @@
+package kit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPasswordAuthApply(t *testing.T) {
+	auth := PasswordAuth{Password: "s3kr1t"}
+	req := httptest.NewRequest(http.MethodGet, "https://shop.myshopify.com/admin", nil)
+
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply returned an error: %v", err)
+	}
+	if got := req.Header.Get("X-Shopify-Access-Token"); got != "s3kr1t" {
+		t.Errorf("expected X-Shopify-Access-Token to be set, got %q", got)
+	}
+}
+
+func TestJWTAuthSignProducesVerifiableToken(t *testing.T) {
+	auth := JWTAuth{Secret: "shared-secret", Subject: "themekit"}
+
+	token, err := auth.sign()
+	if err != nil {
+		t.Fatalf("sign returned an error: %v", err)
+	}
+
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		t.Fatalf("expected a 3-segment JWT, got %d segments", len(segments))
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		t.Fatalf("could not decode claims segment: %v", err)
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("could not unmarshal claims: %v", err)
+	}
+	if claims.Subject != "themekit" {
+		t.Errorf("expected sub claim %q, got %q", "themekit", claims.Subject)
+	}
+
+	mac := hmac.New(sha256.New, []byte(auth.Secret))
+	mac.Write([]byte(segments[0] + "." + segments[1]))
+	expectedSignature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if segments[2] != expectedSignature {
+		t.Error("expected the token's signature to match the recomputed HMAC")
+	}
+}
+
+func TestJWTAuthApplySetsBearerHeader(t *testing.T) {
+	auth := JWTAuth{Secret: "shared-secret", Subject: "themekit"}
+	req := httptest.NewRequest(http.MethodGet, "https://shop.myshopify.com/admin", nil)
+
+	if err := auth.Apply(req); err != nil {
+		t.Fatalf("Apply returned an error: %v", err)
+	}
+	if header := req.Header.Get("Authorization"); !strings.HasPrefix(header, "Bearer ") {
+		t.Errorf("expected a Bearer Authorization header, got %q", header)
+	}
+}
+
+func TestOAuth2AuthCachesAccessToken(t *testing.T) {
+	var refreshes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-from-refresh",
+			"expires_in":   3600,
+		})
+	}))
+	defer server.Close()
+
+	auth := &OAuth2Auth{TokenURL: server.URL}
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "https://shop.myshopify.com/admin", nil)
+		if err := auth.Apply(req); err != nil {
+			t.Fatalf("Apply returned an error on call %d: %v", i, err)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer token-from-refresh" {
+			t.Errorf("expected a Bearer header with the refreshed token, got %q", got)
+		}
+	}
+
+	if refreshes != 1 {
+		t.Errorf("expected the access token to be refreshed once and then cached, got %d refreshes", refreshes)
+	}
+}
+
+func TestConfigurationAuthenticatorIsCached(t *testing.T) {
+	conf := &Configuration{Auth: AuthConfig{Mode: "oauth2"}}
+
+	first := conf.Authenticator()
+	second := conf.Authenticator()
+
+	firstOAuth, ok := first.(*OAuth2Auth)
+	if !ok {
+		t.Fatalf("expected an *OAuth2Auth, got %T", first)
+	}
+	secondOAuth, ok := second.(*OAuth2Auth)
+	if !ok {
+		t.Fatalf("expected an *OAuth2Auth, got %T", second)
+	}
+	if firstOAuth != secondOAuth {
+		t.Error("expected Authenticator to return the same cached *OAuth2Auth instance across calls")
+	}
+}
+
+// TestCompiledConfigurationAuthenticatorIsSafeForConcurrentAddHeaders
+// exercises the pattern Concurrency implies: one compiled Configuration,
+// shared across goroutines, each calling AddHeaders. compile() builds the
+// Authenticator up front so this only ever reads the cache concurrently,
+// never races to build it; run with -race to check.
+func TestCompiledConfigurationAuthenticatorIsSafeForConcurrentAddHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-from-refresh",
+			"expires_in":   3600,
+		})
+	}))
+	defer server.Close()
+
+	conf := Configuration{
+		Domain:  "shop.myshopify.com",
+		ThemeID: "live",
+		Auth:    AuthConfig{Mode: "oauth2", TokenURL: server.URL},
+	}
+
+	compiled, err := conf.compile()
+	if err != nil {
+		t.Fatalf("compile returned an error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "https://shop.myshopify.com/admin", nil)
+			errs <- compiled.AddHeaders(req)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("AddHeaders returned an error: %v", err)
+		}
+	}
+}
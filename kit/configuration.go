@@ -18,17 +18,59 @@ import (
 // Configuration is the structure of a configuration for an environment. This will
 // get loaded into a theme client to dictate it's actions.
 type Configuration struct {
-	Password     string        `yaml:"password,omitempty" env:"THEMEKIT_PASSWORD"`
-	ThemeID      string        `yaml:"theme_id,omitempty" env:"THEMEKIT_THEME_ID"`
-	Domain       string        `yaml:"store" env:"THEMEKIT_DOMAIN"`
-	Directory    string        `yaml:"-" env:"THEMEKIT_DIRECTORY"`
-	IgnoredFiles []string      `yaml:"ignore_files,omitempty" env:"THEMEKIT_IGNORE_FILES" envSeparator:":"`
-	BucketSize   int           `yaml:"bucket_size" env:"THEMEKIT_BUCKET_SIZE"`
-	RefillRate   int           `yaml:"refill_rate" env:"THEMEKIT_REFILL_RATE"`
-	Concurrency  int           `yaml:"concurrency,omitempty" env:"THEMEKIT_CONCURRENCY"`
-	Proxy        string        `yaml:"proxy,omitempty" env:"THEMEKIT_PROXY"`
-	Ignores      []string      `yaml:"ignores,omitempty" env:"THEMEKIT_IGNORES" envSeparator:":"`
-	Timeout      time.Duration `yaml:"timeout,omitempty" env:"THEMEKIT_TIMEOUT"`
+	Password     EncryptedString `yaml:"password,omitempty" env:"THEMEKIT_PASSWORD"`
+	ThemeID      string          `yaml:"theme_id,omitempty" env:"THEMEKIT_THEME_ID"`
+	Domain       string          `yaml:"store" env:"THEMEKIT_DOMAIN"`
+	Directory    string          `yaml:"-" env:"THEMEKIT_DIRECTORY"`
+	IgnoredFiles []string        `yaml:"ignore_files,omitempty" env:"THEMEKIT_IGNORE_FILES" envSeparator:":"`
+	BucketSize   int             `yaml:"bucket_size" env:"THEMEKIT_BUCKET_SIZE"`
+	RefillRate   int             `yaml:"refill_rate" env:"THEMEKIT_REFILL_RATE"`
+	Concurrency  int             `yaml:"concurrency,omitempty" env:"THEMEKIT_CONCURRENCY"`
+	Proxy        string          `yaml:"proxy,omitempty" env:"THEMEKIT_PROXY"`
+	Ignores      []string        `yaml:"ignores,omitempty" env:"THEMEKIT_IGNORES" envSeparator:":"`
+	Timeout      time.Duration   `yaml:"timeout,omitempty" env:"THEMEKIT_TIMEOUT"`
+	Auth         AuthConfig      `yaml:"auth,omitempty"`
+	Retry        RetryPolicy     `yaml:"retry,omitempty"`
+	Log          LogConfig       `yaml:"log,omitempty"`
+	MetricsAddr  string          `yaml:"metrics_addr,omitempty" env:"THEMEKIT_METRICS_ADDR"`
+
+	// authenticator caches the Authenticator built by Authenticator so that
+	// stateful schemes (OAuth2Auth's token cache) survive across repeated
+	// calls instead of refreshing on every request. compile() populates it
+	// up front so concurrent callers only ever read it; see Authenticator's
+	// doc comment.
+	authenticator Authenticator
+}
+
+// LogConfig configures the structured Logger returned by NewLogger.
+type LogConfig struct {
+	Level  string `yaml:"level,omitempty" env:"THEMEKIT_LOG_LEVEL"`
+	Format string `yaml:"format,omitempty" env:"THEMEKIT_LOG_FORMAT"`
+	Output string `yaml:"output,omitempty" env:"THEMEKIT_LOG_OUTPUT"`
+}
+
+// RetryPolicy configures how failed API requests are retried with
+// exponential backoff before giving up.
+type RetryPolicy struct {
+	MaxAttempts     int           `yaml:"max_attempts,omitempty" env:"THEMEKIT_RETRY_MAX_ATTEMPTS"`
+	InitialInterval time.Duration `yaml:"initial_interval,omitempty" env:"THEMEKIT_RETRY_INITIAL_INTERVAL"`
+	MaxInterval     time.Duration `yaml:"max_interval,omitempty" env:"THEMEKIT_RETRY_MAX_INTERVAL"`
+	Multiplier      float64       `yaml:"multiplier,omitempty" env:"THEMEKIT_RETRY_MULTIPLIER"`
+	RetryOn         []int         `yaml:"retry_on,omitempty" env:"THEMEKIT_RETRY_ON" envSeparator:":"`
+}
+
+// AuthConfig selects and configures how requests to the Shopify Admin API
+// are authenticated. Mode chooses the Authenticator that AddHeaders uses;
+// the remaining fields are only consulted for the selected mode.
+type AuthConfig struct {
+	Mode         string        `yaml:"mode,omitempty" env:"THEMEKIT_AUTH_MODE"`
+	ClientID     string        `yaml:"client_id,omitempty" env:"THEMEKIT_AUTH_CLIENT_ID"`
+	ClientSecret string        `yaml:"client_secret,omitempty" env:"THEMEKIT_AUTH_CLIENT_SECRET"`
+	RefreshToken string        `yaml:"refresh_token,omitempty" env:"THEMEKIT_AUTH_REFRESH_TOKEN"`
+	TokenURL     string        `yaml:"token_url,omitempty" env:"THEMEKIT_AUTH_TOKEN_URL"`
+	Secret       string        `yaml:"secret,omitempty" env:"THEMEKIT_AUTH_SECRET"`
+	Subject      string        `yaml:"subject,omitempty" env:"THEMEKIT_AUTH_SUBJECT"`
+	ExpiresIn    time.Duration `yaml:"expires_in,omitempty" env:"THEMEKIT_AUTH_EXPIRES_IN"`
 }
 
 const (
@@ -40,8 +82,21 @@ const (
 	DefaultConcurrency int = 2
 	// DefaultTimeout is the default timeout to kill any stalled processes.
 	DefaultTimeout = 30 * time.Second
+	// DefaultRetryMaxAttempts is the default number of times a failed API
+	// request is retried before giving up.
+	DefaultRetryMaxAttempts int = 5
+	// DefaultRetryInitialInterval is the default wait before the first retry.
+	DefaultRetryInitialInterval = 500 * time.Millisecond
+	// DefaultRetryMaxInterval is the default ceiling on the backoff delay.
+	DefaultRetryMaxInterval = 30 * time.Second
+	// DefaultRetryMultiplier is the default backoff growth factor between attempts.
+	DefaultRetryMultiplier float64 = 2.0
 )
 
+// DefaultRetryOn is the default set of response status codes that are
+// retried: Shopify's rate-limit code plus the common transient 5xx codes.
+var DefaultRetryOn = []int{429, 500, 502, 503, 504}
+
 var (
 	defaultConfig     = Configuration{}
 	environmentConfig = Configuration{}
@@ -57,6 +112,13 @@ func init() {
 		RefillRate:  DefaultRefillRate,
 		Concurrency: DefaultConcurrency,
 		Timeout:     DefaultTimeout,
+		Retry: RetryPolicy{
+			MaxAttempts:     DefaultRetryMaxAttempts,
+			InitialInterval: DefaultRetryInitialInterval,
+			MaxInterval:     DefaultRetryMaxInterval,
+			Multiplier:      DefaultRetryMultiplier,
+			RetryOn:         DefaultRetryOn,
+		},
 	}
 
 	environmentConfig = Configuration{}
@@ -81,34 +143,18 @@ func (conf Configuration) compile() (Configuration, error) {
 	mergo.Merge(&newConfig, &environmentConfig)
 	mergo.Merge(&newConfig, &conf)
 	mergo.Merge(&newConfig, &defaultConfig)
-	return newConfig, newConfig.Validate()
-}
-
-func (conf Configuration) Validate() error {
-	errors := []string{}
 
-	if conf.ThemeID == "" {
-		errors = append(errors, "missing theme_id")
-	} else if !conf.IsLive() {
-		if _, err := strconv.ParseInt(conf.ThemeID, 10, 64); err != nil {
-			errors = append(errors, "invalid theme_id")
-		}
-	}
-
-	if len(conf.Domain) == 0 {
-		errors = append(errors, "missing domain")
-	} else if !strings.HasSuffix(conf.Domain, "myshopify.com") && !strings.HasSuffix(conf.Domain, "myshopify.io") {
-		errors = append(errors, "invalid domain, must end in '.myshopify.com'")
+	if err := newConfig.Validate(); err != nil {
+		return newConfig, err
 	}
 
-	if len(conf.Password) == 0 {
-		errors = append(errors, "missing password")
-	}
+	// Build the Authenticator now, while newConfig is still only visible to
+	// this goroutine, so the concurrent callers of AddHeaders that
+	// Configuration.Concurrency implies only ever read the cache instead of
+	// racing to build and assign it.
+	newConfig.Authenticator()
 
-	if len(errors) > 0 {
-		return fmt.Errorf("Invalid configuration: %v", strings.Join(errors, ","))
-	}
-	return nil
+	return newConfig, nil
 }
 
 // AdminURL will return the url to the shopify admin.
@@ -151,11 +197,19 @@ func (conf Configuration) AssetPath() string {
 }
 
 // AddHeaders will add api headers to an http.Requests so that it is a valid request.
-func (conf Configuration) AddHeaders(req *http.Request) {
-	req.Header.Add("X-Shopify-Access-Token", conf.Password)
+// It returns any error Apply encountered authenticating the request, logging
+// it first so a failed refresh is never silently shipped as an
+// unauthenticated request.
+func (conf *Configuration) AddHeaders(req *http.Request) error {
+	err := conf.Authenticator().Apply(req)
+	if err != nil {
+		NewLogger(*conf).Error("", "could not authenticate request", map[string]interface{}{"error": err.Error()})
+	}
+
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", fmt.Sprintf("go/themekit (%s; %s)", runtime.GOOS, runtime.GOARCH))
+	return err
 }
 
 // String will return a formatted string with the information about this configuration
@@ -0,0 +1,145 @@
+package kit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Severity categorizes a Diagnostic's impact.
+type Severity string
+
+const (
+	SeverityError Severity = "error"
+	SeverityWarn  Severity = "warn"
+	SeverityInfo  Severity = "info"
+)
+
+// Diagnostic is a single result of Configuration.Doctor.
+type Diagnostic struct {
+	Check    string
+	Severity Severity
+	Message  string
+}
+
+// Doctor runs live checks against the environment described by conf --
+// DNS resolution, reachability of the admin API, whether the theme
+// actually exists, proxy reachability and whether the write directory is
+// writable -- so users can self-diagnose beyond what static Validate can
+// catch.
+func (conf Configuration) Doctor(ctx context.Context) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	if err := conf.Validate(); err != nil {
+		diagnostics = append(diagnostics, Diagnostic{Check: "config", Severity: SeverityError, Message: err.Error()})
+	}
+
+	diagnostics = append(diagnostics, conf.checkDomain(ctx))
+	diagnostics = append(diagnostics, conf.checkAdminURL(ctx))
+	diagnostics = append(diagnostics, conf.checkTheme(ctx))
+	if conf.Proxy != "" {
+		diagnostics = append(diagnostics, conf.checkProxy(ctx))
+	}
+	diagnostics = append(diagnostics, conf.checkDirectory())
+
+	return diagnostics
+}
+
+func (conf Configuration) checkDomain(ctx context.Context) Diagnostic {
+	resolver := net.Resolver{}
+	if _, err := resolver.LookupHost(ctx, conf.Domain); err != nil {
+		return Diagnostic{Check: "dns", Severity: SeverityError, Message: fmt.Sprintf("could not resolve %s: %v", conf.Domain, err)}
+	}
+	return Diagnostic{Check: "dns", Severity: SeverityInfo, Message: fmt.Sprintf("%s resolves", conf.Domain)}
+}
+
+func (conf Configuration) checkAdminURL(ctx context.Context) Diagnostic {
+	req, err := http.NewRequest("HEAD", conf.AdminURL()+".json", nil)
+	if err != nil {
+		return Diagnostic{Check: "admin_url", Severity: SeverityError, Message: err.Error()}
+	}
+	req = req.WithContext(ctx)
+	conf.AddHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Diagnostic{Check: "admin_url", Severity: SeverityError, Message: fmt.Sprintf("could not reach %s: %v", conf.AdminURL(), err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Diagnostic{Check: "admin_url", Severity: SeverityError, Message: fmt.Sprintf("%s returned %d, check your password", conf.AdminURL(), resp.StatusCode)}
+	}
+	return Diagnostic{Check: "admin_url", Severity: SeverityInfo, Message: "admin url is reachable with the configured token"}
+}
+
+func (conf Configuration) checkTheme(ctx context.Context) Diagnostic {
+	if conf.IsLive() {
+		return Diagnostic{Check: "theme", Severity: SeverityInfo, Message: `theme_id is "live", skipping existence check`}
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/admin/themes.json", conf.Domain), nil)
+	if err != nil {
+		return Diagnostic{Check: "theme", Severity: SeverityError, Message: err.Error()}
+	}
+	req = req.WithContext(ctx)
+	conf.AddHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Diagnostic{Check: "theme", Severity: SeverityError, Message: fmt.Sprintf("could not list themes: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Diagnostic{Check: "theme", Severity: SeverityWarn, Message: fmt.Sprintf("could not verify theme_id %s exists: themes.json returned %d", conf.ThemeID, resp.StatusCode)}
+	}
+
+	var listing struct {
+		Themes []struct {
+			ID int64 `json:"id"`
+		} `json:"themes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return Diagnostic{Check: "theme", Severity: SeverityWarn, Message: fmt.Sprintf("could not parse themes.json: %v", err)}
+	}
+
+	themeID, err := strconv.ParseInt(conf.ThemeID, 10, 64)
+	if err != nil {
+		return Diagnostic{Check: "theme", Severity: SeverityError, Message: fmt.Sprintf("invalid theme_id %q: %v", conf.ThemeID, err)}
+	}
+
+	for _, theme := range listing.Themes {
+		if theme.ID == themeID {
+			return Diagnostic{Check: "theme", Severity: SeverityInfo, Message: fmt.Sprintf("theme_id %s exists", conf.ThemeID)}
+		}
+	}
+
+	return Diagnostic{Check: "theme", Severity: SeverityError, Message: fmt.Sprintf("theme_id %s was not found in themes.json", conf.ThemeID)}
+}
+
+func (conf Configuration) checkProxy(ctx context.Context) Diagnostic {
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", conf.Proxy)
+	if err != nil {
+		return Diagnostic{Check: "proxy", Severity: SeverityError, Message: fmt.Sprintf("could not reach proxy %s: %v", conf.Proxy, err)}
+	}
+	conn.Close()
+	return Diagnostic{Check: "proxy", Severity: SeverityInfo, Message: fmt.Sprintf("proxy %s is reachable", conf.Proxy)}
+}
+
+func (conf Configuration) checkDirectory() Diagnostic {
+	testFile := fmt.Sprintf("%s/.themekit-doctor-write-test", conf.Directory)
+	file, err := os.Create(testFile)
+	if err != nil {
+		return Diagnostic{Check: "directory", Severity: SeverityError, Message: fmt.Sprintf("%s is not writable: %v", conf.Directory, err)}
+	}
+	file.Close()
+	os.Remove(testFile)
+	return Diagnostic{Check: "directory", Severity: SeverityInfo, Message: fmt.Sprintf("%s is writable", conf.Directory)}
+}
@@ -0,0 +1,145 @@
+package kit
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// withTestDefaultClient points http.DefaultClient at server's trusted
+// transport for the duration of the test, since checkAdminURL/checkTheme
+// use http.DefaultClient directly rather than conf.HTTPClient().
+func withTestDefaultClient(t *testing.T, server *httptest.Server) {
+	t.Helper()
+	original := http.DefaultClient.Transport
+	http.DefaultClient.Transport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultClient.Transport = original })
+}
+
+func TestCheckThemeFindsExistingTheme(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"themes": []map[string]interface{}{{"id": 123}},
+		})
+	}))
+	defer server.Close()
+	withTestDefaultClient(t, server)
+
+	conf := Configuration{Domain: strings.TrimPrefix(server.URL, "https://"), ThemeID: "123"}
+	diagnostic := conf.checkTheme(context.Background())
+
+	if diagnostic.Severity != SeverityInfo {
+		t.Errorf("expected an existing theme_id to be SeverityInfo, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckThemeReportsMissingTheme(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"themes": []map[string]interface{}{{"id": 999}},
+		})
+	}))
+	defer server.Close()
+	withTestDefaultClient(t, server)
+
+	conf := Configuration{Domain: strings.TrimPrefix(server.URL, "https://"), ThemeID: "123"}
+	diagnostic := conf.checkTheme(context.Background())
+
+	if diagnostic.Severity != SeverityError {
+		t.Errorf("expected a missing theme_id to be SeverityError, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckThemeSkipsLiveTheme(t *testing.T) {
+	conf := Configuration{ThemeID: "live"}
+	diagnostic := conf.checkTheme(context.Background())
+
+	if diagnostic.Severity != SeverityInfo {
+		t.Errorf("expected theme_id \"live\" to skip the existence check, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckAdminURLReachable(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	withTestDefaultClient(t, server)
+
+	conf := Configuration{Domain: strings.TrimPrefix(server.URL, "https://"), ThemeID: "live"}
+	diagnostic := conf.checkAdminURL(context.Background())
+
+	if diagnostic.Severity != SeverityInfo {
+		t.Errorf("expected a reachable admin url to be SeverityInfo, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckAdminURLUnauthorized(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	withTestDefaultClient(t, server)
+
+	conf := Configuration{Domain: strings.TrimPrefix(server.URL, "https://"), ThemeID: "live"}
+	diagnostic := conf.checkAdminURL(context.Background())
+
+	if diagnostic.Severity != SeverityError {
+		t.Errorf("expected a 401 admin url response to be SeverityError, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckDirectoryWritable(t *testing.T) {
+	conf := Configuration{Directory: t.TempDir()}
+	diagnostic := conf.checkDirectory()
+
+	if diagnostic.Severity != SeverityInfo {
+		t.Errorf("expected a writable directory to be SeverityInfo, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckDirectoryNotWritable(t *testing.T) {
+	conf := Configuration{Directory: "/nonexistent/path/that/does/not/exist"}
+	diagnostic := conf.checkDirectory()
+
+	if diagnostic.Severity != SeverityError {
+		t.Errorf("expected an unwritable directory to be SeverityError, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckProxyReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not start a listener: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	conf := Configuration{Proxy: listener.Addr().String()}
+	diagnostic := conf.checkProxy(context.Background())
+
+	if diagnostic.Severity != SeverityInfo {
+		t.Errorf("expected a reachable proxy to be SeverityInfo, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
+
+func TestCheckProxyUnreachable(t *testing.T) {
+	conf := Configuration{Proxy: "127.0.0.1:1"}
+	diagnostic := conf.checkProxy(context.Background())
+
+	if diagnostic.Severity != SeverityError {
+		t.Errorf("expected an unreachable proxy to be SeverityError, got %s: %s", diagnostic.Severity, diagnostic.Message)
+	}
+}
@@ -0,0 +1,112 @@
+package kit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/imdario/mergo"
+	"gopkg.in/yaml.v1"
+)
+
+// environmentEntry is the raw, unresolved shape of a single named section
+// in a multi-environment config file. It mirrors Configuration's file-backed
+// fields plus InheritFrom, which names another section to merge underneath
+// this one before it is compiled into a Configuration.
+type environmentEntry struct {
+	InheritFrom  string          `yaml:"inherit_from,omitempty"`
+	Password     EncryptedString `yaml:"password,omitempty"`
+	ThemeID      string          `yaml:"theme_id,omitempty"`
+	Domain       string          `yaml:"store"`
+	IgnoredFiles []string        `yaml:"ignore_files,omitempty"`
+	BucketSize   int             `yaml:"bucket_size"`
+	RefillRate   int             `yaml:"refill_rate"`
+	Concurrency  int             `yaml:"concurrency,omitempty"`
+	Proxy        string          `yaml:"proxy,omitempty"`
+	Ignores      []string        `yaml:"ignores,omitempty"`
+	Timeout      time.Duration   `yaml:"timeout,omitempty"`
+	Auth         AuthConfig      `yaml:"auth,omitempty"`
+	Retry        RetryPolicy     `yaml:"retry,omitempty"`
+	Log          LogConfig       `yaml:"log,omitempty"`
+	MetricsAddr  string          `yaml:"metrics_addr,omitempty"`
+}
+
+// Environments is a set of named Configuration overlays loaded from a
+// multi-environment config file (e.g. "development", "staging",
+// "production"), keyed by environment name.
+type Environments map[string]environmentEntry
+
+// LoadEnvironments reads and parses a multi-environment config file at path.
+func LoadEnvironments(path string) (Environments, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	envs := Environments{}
+	if err := yaml.Unmarshal(bytes, &envs); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %v", path, err)
+	}
+	return envs, nil
+}
+
+// Get resolves name's inherit_from chain into a single Configuration, then
+// compiles it against environment variables, flags and defaults using the
+// same precedence as NewConfiguration.
+func (envs Environments) Get(name string) (Configuration, error) {
+	if _, ok := envs[name]; !ok {
+		return Configuration{}, fmt.Errorf("no environment named %q", name)
+	}
+
+	resolved, err := envs.resolve(name, map[string]bool{})
+	if err != nil {
+		return Configuration{}, err
+	}
+
+	conf := Configuration{
+		Password:     resolved.Password,
+		ThemeID:      resolved.ThemeID,
+		Domain:       resolved.Domain,
+		IgnoredFiles: resolved.IgnoredFiles,
+		BucketSize:   resolved.BucketSize,
+		RefillRate:   resolved.RefillRate,
+		Concurrency:  resolved.Concurrency,
+		Proxy:        resolved.Proxy,
+		Ignores:      resolved.Ignores,
+		Timeout:      resolved.Timeout,
+		Auth:         resolved.Auth,
+		Retry:        resolved.Retry,
+		Log:          resolved.Log,
+		MetricsAddr:  resolved.MetricsAddr,
+	}
+
+	return conf.compile()
+}
+
+// resolve walks the inherit_from chain for name, merging the parent entry
+// underneath the child so the child's own fields win. visiting guards
+// against circular inherit_from chains.
+func (envs Environments) resolve(name string, visiting map[string]bool) (environmentEntry, error) {
+	entry, ok := envs[name]
+	if !ok {
+		return environmentEntry{}, fmt.Errorf("unknown environment %q", name)
+	}
+	if visiting[name] {
+		return environmentEntry{}, fmt.Errorf("circular inherit_from chain at %q", name)
+	}
+	visiting[name] = true
+
+	if entry.InheritFrom == "" {
+		return entry, nil
+	}
+
+	parent, err := envs.resolve(entry.InheritFrom, visiting)
+	if err != nil {
+		return environmentEntry{}, err
+	}
+
+	merged := entry
+	mergo.Merge(&merged, &parent)
+	merged.InheritFrom = ""
+	return merged, nil
+}
@@ -0,0 +1,106 @@
+package kit
+
+import "testing"
+
+func TestEnvironmentsResolveMergesInheritFrom(t *testing.T) {
+	envs := Environments{
+		"base": environmentEntry{
+			Domain:     "shop.myshopify.com",
+			BucketSize: 40,
+		},
+		"production": environmentEntry{
+			InheritFrom: "base",
+			ThemeID:     "123",
+		},
+	}
+
+	resolved, err := envs.resolve("production", map[string]bool{})
+	if err != nil {
+		t.Fatalf("resolve returned an error: %v", err)
+	}
+	if resolved.ThemeID != "123" {
+		t.Errorf("expected the child's own ThemeID to win, got %q", resolved.ThemeID)
+	}
+	if resolved.Domain != "shop.myshopify.com" {
+		t.Errorf("expected Domain to be inherited from base, got %q", resolved.Domain)
+	}
+	if resolved.BucketSize != 40 {
+		t.Errorf("expected BucketSize to be inherited from base, got %d", resolved.BucketSize)
+	}
+	if resolved.InheritFrom != "" {
+		t.Error("expected InheritFrom to be cleared on the resolved entry")
+	}
+}
+
+func TestEnvironmentsResolveChildOverridesParent(t *testing.T) {
+	envs := Environments{
+		"base": environmentEntry{ThemeID: "base-theme"},
+		"production": environmentEntry{
+			InheritFrom: "base",
+			ThemeID:     "production-theme",
+		},
+	}
+
+	resolved, err := envs.resolve("production", map[string]bool{})
+	if err != nil {
+		t.Fatalf("resolve returned an error: %v", err)
+	}
+	if resolved.ThemeID != "production-theme" {
+		t.Errorf("expected the child's ThemeID to override the parent's, got %q", resolved.ThemeID)
+	}
+}
+
+func TestEnvironmentsResolveDetectsCircularInheritFrom(t *testing.T) {
+	envs := Environments{
+		"a": environmentEntry{InheritFrom: "b"},
+		"b": environmentEntry{InheritFrom: "a"},
+	}
+
+	if _, err := envs.resolve("a", map[string]bool{}); err == nil {
+		t.Error("expected a circular inherit_from chain to return an error")
+	}
+}
+
+func TestEnvironmentsResolveUnknownParent(t *testing.T) {
+	envs := Environments{
+		"production": environmentEntry{InheritFrom: "missing"},
+	}
+
+	if _, err := envs.resolve("production", map[string]bool{}); err == nil {
+		t.Error("expected an unknown inherit_from target to return an error")
+	}
+}
+
+func TestEnvironmentsGetUnknownEnvironment(t *testing.T) {
+	envs := Environments{}
+
+	if _, err := envs.Get("production"); err == nil {
+		t.Error("expected Get to return an error for an unknown environment")
+	}
+}
+
+func TestEnvironmentsGetResolvesAndCompiles(t *testing.T) {
+	envs := Environments{
+		"base": environmentEntry{
+			Domain:     "shop.myshopify.com",
+			Password:   EncryptedString{Plaintext: "s3kr1t"},
+			BucketSize: 40,
+			RefillRate: 2,
+		},
+		"production": environmentEntry{
+			InheritFrom: "base",
+			ThemeID:     "123",
+		},
+	}
+
+	conf, err := envs.Get("production")
+	if err != nil {
+		t.Fatalf("Get returned an error: %v", err)
+	}
+	if conf.Domain != "shop.myshopify.com" {
+		t.Errorf("expected the compiled Configuration to carry the inherited Domain, got %q", conf.Domain)
+	}
+	if conf.ThemeID != "123" {
+		t.Errorf("expected the compiled Configuration to carry the child's ThemeID, got %q", conf.ThemeID)
+	}
+}
@@ -0,0 +1,176 @@
+package kit
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreRule is a single parsed .gitignore-style pattern.
+type ignoreRule struct {
+	raw      string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// Ignorer matches file paths against an ordered set of .gitignore-style
+// rules: globs, "**", a leading "!" to negate a previous match, a trailing
+// "/" to match directories only, and a leading "/" to anchor to the root.
+// The last matching rule wins.
+type Ignorer struct {
+	rules []ignoreRule
+}
+
+// Ignorer builds an Ignorer from the configured Ignores and IgnoredFiles
+// patterns, in that order.
+func (conf Configuration) Ignorer() *Ignorer {
+	patterns := append([]string{}, conf.Ignores...)
+	patterns = append(patterns, conf.IgnoredFiles...)
+	return NewIgnorer(patterns)
+}
+
+// NewIgnorer parses patterns, in order, into an Ignorer.
+func NewIgnorer(patterns []string) *Ignorer {
+	ignorer := &Ignorer{}
+	for _, pattern := range patterns {
+		ignorer.add(pattern)
+	}
+	return ignorer
+}
+
+// LoadIgnoreFile appends the patterns found in an external
+// .themekitignore-style file, one pattern per line, blank lines and '#'
+// comments skipped.
+func (ignorer *Ignorer) LoadIgnoreFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ignorer.add(line)
+	}
+	return scanner.Err()
+}
+
+func (ignorer *Ignorer) add(raw string) {
+	rule := ignoreRule{raw: raw}
+	pattern := raw
+
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = pattern[1:]
+	}
+	if strings.HasPrefix(pattern, "/") {
+		rule.anchored = true
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if strings.Contains(pattern, "/") {
+		rule.anchored = true
+	}
+
+	rule.pattern = pattern
+	ignorer.rules = append(ignorer.rules, rule)
+}
+
+// MatchResult reports whether a path was ignored and which rule, if any,
+// decided it; a negation rule that re-includes the path is reported too.
+type MatchResult struct {
+	Ignored bool
+	Rule    string
+}
+
+// Matches reports whether path is ignored.
+func (ignorer *Ignorer) Matches(path string) bool {
+	return ignorer.Trace(path).Ignored
+}
+
+// Trace is like Matches but also returns which rule decided the result, for
+// "themekit ignore test <path>" style debugging.
+func (ignorer *Ignorer) Trace(p string) MatchResult {
+	p = filepath.ToSlash(p)
+	result := MatchResult{}
+
+	for _, rule := range ignorer.rules {
+		if rule.matches(p) {
+			result.Ignored = !rule.negate
+			result.Rule = rule.raw
+		}
+	}
+	return result
+}
+
+func (rule ignoreRule) matches(p string) bool {
+	candidate := strings.TrimSuffix(p, "/")
+
+	if rule.anchored {
+		if rule.dirOnly {
+			return candidate == rule.pattern || strings.HasPrefix(candidate, rule.pattern+"/")
+		}
+		return matchGlob(rule.pattern, candidate)
+	}
+
+	// Unanchored patterns may match at any path depth, mirroring
+	// .gitignore's treatment of a pattern with no slash in it.
+	segments := strings.Split(candidate, "/")
+	for i := range segments {
+		suffix := strings.Join(segments[i:], "/")
+		if rule.dirOnly && (suffix == rule.pattern || strings.HasPrefix(suffix, rule.pattern+"/")) {
+			return true
+		}
+		if matchGlob(rule.pattern, suffix) {
+			return true
+		}
+		if ok, _ := path.Match(rule.pattern, segments[i]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches pattern against name, supporting "**" (matching any
+// number of path segments, including none) in addition to path.Match's
+// single-segment globs.
+func matchGlob(pattern, name string) bool {
+	if !strings.Contains(pattern, "**") {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}
+
+	parts := strings.SplitN(pattern, "**", 2)
+	prefix := strings.TrimSuffix(parts[0], "/")
+	suffix := strings.TrimPrefix(parts[1], "/")
+
+	if prefix != "" && name != prefix && !strings.HasPrefix(name, prefix+"/") {
+		return false
+	}
+
+	remainder := strings.TrimPrefix(strings.TrimPrefix(name, prefix), "/")
+	if suffix == "" {
+		return true
+	}
+
+	// "**" may absorb any number of intermediate directories, so try
+	// matching suffix against the whole remainder and every shorter tail.
+	segments := strings.Split(remainder, "/")
+	for i := 0; i < len(segments); i++ {
+		if ok, _ := path.Match(suffix, strings.Join(segments[i:], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
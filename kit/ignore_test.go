@@ -0,0 +1,50 @@
+package kit
+
+import "testing"
+
+func TestIgnorerMatchesAnchoredDirectory(t *testing.T) {
+	ignorer := NewIgnorer([]string{"/build/"})
+
+	if !ignorer.Matches("build/output.txt") {
+		t.Error("expected build/output.txt to be ignored by /build/")
+	}
+	if !ignorer.Matches("build") {
+		t.Error("expected build itself to be ignored by /build/")
+	}
+	if ignorer.Matches("src/build/output.txt") {
+		t.Error("expected src/build/output.txt not to match anchored /build/")
+	}
+}
+
+func TestIgnorerMatchesUnanchoredDirectory(t *testing.T) {
+	ignorer := NewIgnorer([]string{"node_modules/"})
+
+	if !ignorer.Matches("node_modules/lib/index.js") {
+		t.Error("expected nested node_modules/ contents to be ignored")
+	}
+	if !ignorer.Matches("assets/node_modules/lib/index.js") {
+		t.Error("expected node_modules/ to match at any depth")
+	}
+}
+
+func TestIgnorerNegation(t *testing.T) {
+	ignorer := NewIgnorer([]string{"*.liquid", "!templates/keep.liquid"})
+
+	if !ignorer.Matches("templates/drop.liquid") {
+		t.Error("expected drop.liquid to be ignored")
+	}
+	if ignorer.Matches("templates/keep.liquid") {
+		t.Error("expected keep.liquid to be re-included by the negation rule")
+	}
+}
+
+func TestIgnorerDoubleStar(t *testing.T) {
+	ignorer := NewIgnorer([]string{"assets/**/*.min.js"})
+
+	if !ignorer.Matches("assets/vendor/jquery.min.js") {
+		t.Error("expected ** to match across directories")
+	}
+	if ignorer.Matches("assets/vendor/jquery.js") {
+		t.Error("did not expect a non-matching suffix to be ignored")
+	}
+}
@@ -0,0 +1,136 @@
+package kit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel ranks log severity from most to least verbose.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (level LogLevel) String() string {
+	switch level {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+func parseLogLevel(level string) LogLevel {
+	switch strings.ToLower(level) {
+	case "debug":
+		return LogLevelDebug
+	case "warn":
+		return LogLevelWarn
+	case "error":
+		return LogLevelError
+	default:
+		return LogLevelInfo
+	}
+}
+
+// Logger is the structured logging interface used throughout the kit
+// package in place of ad-hoc fmt/log calls, so `watch` output can carry
+// levels and request IDs and be consumed as JSON in CI.
+type Logger interface {
+	Debug(requestID, msg string, fields map[string]interface{})
+	Info(requestID, msg string, fields map[string]interface{})
+	Warn(requestID, msg string, fields map[string]interface{})
+	Error(requestID, msg string, fields map[string]interface{})
+}
+
+// stdLogger is the default Logger, writing either plain text or JSON lines
+// to Output depending on Format.
+type stdLogger struct {
+	mu     sync.Mutex
+	level  LogLevel
+	format string
+	output io.Writer
+}
+
+// NewLogger builds the Logger described by conf.Log, defaulting to
+// plain-text info-level logging to stderr.
+func NewLogger(conf Configuration) Logger {
+	output := io.Writer(os.Stderr)
+	switch conf.Log.Output {
+	case "", "stderr":
+		output = os.Stderr
+	case "stdout":
+		output = os.Stdout
+	default:
+		if file, err := os.OpenFile(conf.Log.Output, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644); err == nil {
+			output = file
+		}
+	}
+
+	format := conf.Log.Format
+	if format == "" {
+		format = "text"
+	}
+
+	return &stdLogger{
+		level:  parseLogLevel(conf.Log.Level),
+		format: format,
+		output: output,
+	}
+}
+
+func (logger *stdLogger) log(level LogLevel, requestID, msg string, fields map[string]interface{}) {
+	if level < logger.level {
+		return
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if logger.format == "json" {
+		entry := map[string]interface{}{
+			"time":       time.Now().Format(time.RFC3339),
+			"level":      level.String(),
+			"message":    msg,
+			"request_id": requestID,
+		}
+		for key, value := range fields {
+			entry[key] = value
+		}
+		if bytes, err := json.Marshal(entry); err == nil {
+			fmt.Fprintln(logger.output, string(bytes))
+		}
+		return
+	}
+
+	fmt.Fprintf(logger.output, "[%s] %-5s %s %s %v\n", time.Now().Format(time.RFC3339), level, requestID, msg, fields)
+}
+
+func (logger *stdLogger) Debug(requestID, msg string, fields map[string]interface{}) {
+	logger.log(LogLevelDebug, requestID, msg, fields)
+}
+
+func (logger *stdLogger) Info(requestID, msg string, fields map[string]interface{}) {
+	logger.log(LogLevelInfo, requestID, msg, fields)
+}
+
+func (logger *stdLogger) Warn(requestID, msg string, fields map[string]interface{}) {
+	logger.log(LogLevelWarn, requestID, msg, fields)
+}
+
+func (logger *stdLogger) Error(requestID, msg string, fields map[string]interface{}) {
+	logger.log(LogLevelError, requestID, msg, fields)
+}
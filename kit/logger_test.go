@@ -0,0 +1,112 @@
+package kit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	cases := map[string]LogLevel{
+		"debug": LogLevelDebug,
+		"DEBUG": LogLevelDebug,
+		"warn":  LogLevelWarn,
+		"error": LogLevelError,
+		"info":  LogLevelInfo,
+		"":      LogLevelInfo,
+		"bogus": LogLevelInfo,
+	}
+	for input, want := range cases {
+		if got := parseLogLevel(input); got != want {
+			t.Errorf("parseLogLevel(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestLogLevelString(t *testing.T) {
+	cases := map[LogLevel]string{
+		LogLevelDebug: "debug",
+		LogLevelInfo:  "info",
+		LogLevelWarn:  "warn",
+		LogLevelError: "error",
+	}
+	for level, want := range cases {
+		if got := level.String(); got != want {
+			t.Errorf("LogLevel(%d).String() = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func newTestLogger(t *testing.T, conf Configuration) (Logger, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "themekit.log")
+	conf.Log.Output = path
+	return NewLogger(conf), path
+}
+
+func TestStdLoggerFiltersBelowConfiguredLevel(t *testing.T) {
+	logger, path := newTestLogger(t, Configuration{Log: LogConfig{Level: "warn"}})
+
+	logger.Debug("", "should be filtered", nil)
+	logger.Info("", "should also be filtered", nil)
+	logger.Warn("", "should be logged", nil)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read log file: %v", err)
+	}
+
+	if strings.Contains(string(contents), "should be filtered") || strings.Contains(string(contents), "should also be filtered") {
+		t.Errorf("expected debug/info lines to be filtered out at warn level, got %q", contents)
+	}
+	if !strings.Contains(string(contents), "should be logged") {
+		t.Errorf("expected the warn line to be logged, got %q", contents)
+	}
+}
+
+func TestStdLoggerJSONFormat(t *testing.T) {
+	logger, path := newTestLogger(t, Configuration{Log: LogConfig{Format: "json"}})
+
+	logger.Info("req-1", "hello", map[string]interface{}{"key": "value"})
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read log file: %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		t.Fatalf("expected a single valid JSON line, got %q: %v", contents, err)
+	}
+	if entry["message"] != "hello" {
+		t.Errorf("expected message %q, got %v", "hello", entry["message"])
+	}
+	if entry["level"] != "info" {
+		t.Errorf("expected level %q, got %v", "info", entry["level"])
+	}
+	if entry["request_id"] != "req-1" {
+		t.Errorf("expected request_id %q, got %v", "req-1", entry["request_id"])
+	}
+	if entry["key"] != "value" {
+		t.Errorf("expected the extra field to be merged in, got %v", entry["key"])
+	}
+}
+
+func TestStdLoggerTextFormatIncludesFields(t *testing.T) {
+	logger, path := newTestLogger(t, Configuration{})
+
+	logger.Error("req-2", "something broke", map[string]interface{}{"error": "boom"})
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read log file: %v", err)
+	}
+
+	for _, want := range []string{"error", "req-2", "something broke", "boom"} {
+		if !strings.Contains(string(contents), want) {
+			t.Errorf("expected the text log line to contain %q, got %q", want, contents)
+		}
+	}
+}
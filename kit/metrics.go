@@ -0,0 +1,126 @@
+package kit
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics is a small Prometheus-compatible registry tracking uploads,
+// downloads, errors, API latency and the current leaky-bucket token count
+// for a running themekit process, so throttling and failure patterns are
+// visible to whatever is scraping `/metrics` instead of scrolling logs.
+type Metrics struct {
+	uploads      int64
+	downloads    int64
+	errors       int64
+	bucketTokens int64
+
+	mu        sync.Mutex
+	latencies []float64
+}
+
+// NewMetrics builds an empty Metrics registry.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// DefaultMetrics is the process-wide registry that HTTPClient's retry
+// transport reports against, and that StartMetrics exposes once
+// Configuration.MetricsAddr is set.
+var DefaultMetrics = NewMetrics()
+
+var metricsServerOnce sync.Once
+
+// StartMetrics launches DefaultMetrics' /metrics and /healthz server on
+// conf.MetricsAddr, once per process, if MetricsAddr is set. Compiling a
+// Configuration (NewConfiguration, Environments.Get) never calls this on its
+// own, since most commands are one-shot and have no business binding a
+// listener; call it explicitly from whichever long-running command (e.g.
+// "watch") actually wants metrics exposed.
+func (conf Configuration) StartMetrics() {
+	if conf.MetricsAddr == "" {
+		return
+	}
+
+	metricsServerOnce.Do(func() {
+		logger := NewLogger(conf)
+		go func() {
+			if err := DefaultMetrics.Serve(conf.MetricsAddr); err != nil {
+				logger.Error("", "metrics server stopped", map[string]interface{}{"error": err.Error()})
+			}
+		}()
+	})
+}
+
+// IncUploads records a completed asset upload.
+func (m *Metrics) IncUploads() { atomic.AddInt64(&m.uploads, 1) }
+
+// IncDownloads records a completed asset download.
+func (m *Metrics) IncDownloads() { atomic.AddInt64(&m.downloads, 1) }
+
+// IncErrors records a failed API call.
+func (m *Metrics) IncErrors() { atomic.AddInt64(&m.errors, 1) }
+
+// ObserveLatency records the duration, in seconds, of an API call.
+func (m *Metrics) ObserveLatency(seconds float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies = append(m.latencies, seconds)
+}
+
+// SetBucketTokens gauges the number of leaky-bucket tokens currently available.
+func (m *Metrics) SetBucketTokens(tokens int64) {
+	atomic.StoreInt64(&m.bucketTokens, tokens)
+}
+
+// ServeHTTP renders the registry in Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	latencies := append([]float64{}, m.latencies...)
+	m.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP themekit_uploads_total Total number of asset uploads.\n")
+	fmt.Fprintf(w, "# TYPE themekit_uploads_total counter\n")
+	fmt.Fprintf(w, "themekit_uploads_total %d\n", atomic.LoadInt64(&m.uploads))
+
+	fmt.Fprintf(w, "# HELP themekit_downloads_total Total number of asset downloads.\n")
+	fmt.Fprintf(w, "# TYPE themekit_downloads_total counter\n")
+	fmt.Fprintf(w, "themekit_downloads_total %d\n", atomic.LoadInt64(&m.downloads))
+
+	fmt.Fprintf(w, "# HELP themekit_errors_total Total number of failed API calls.\n")
+	fmt.Fprintf(w, "# TYPE themekit_errors_total counter\n")
+	fmt.Fprintf(w, "themekit_errors_total %d\n", atomic.LoadInt64(&m.errors))
+
+	fmt.Fprintf(w, "# HELP themekit_bucket_tokens Current leaky-bucket tokens available.\n")
+	fmt.Fprintf(w, "# TYPE themekit_bucket_tokens gauge\n")
+	fmt.Fprintf(w, "themekit_bucket_tokens %d\n", atomic.LoadInt64(&m.bucketTokens))
+
+	fmt.Fprintf(w, "# HELP themekit_api_latency_seconds API call latency.\n")
+	fmt.Fprintf(w, "# TYPE themekit_api_latency_seconds histogram\n")
+	for _, bound := range []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10} {
+		count := 0
+		for _, latency := range latencies {
+			if latency <= bound {
+				count++
+			}
+		}
+		fmt.Fprintf(w, "themekit_api_latency_seconds_bucket{le=\"%g\"} %d\n", bound, count)
+	}
+	fmt.Fprintf(w, "themekit_api_latency_seconds_bucket{le=\"+Inf\"} %d\n", len(latencies))
+	fmt.Fprintf(w, "themekit_api_latency_seconds_count %d\n", len(latencies))
+}
+
+// Serve starts an HTTP server on addr exposing /metrics and /healthz. It
+// blocks for the life of the server, mirroring how long `themekit watch`
+// itself runs.
+func (m *Metrics) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,166 @@
+package kit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryTransport wraps an http.RoundTripper and retries requests that fail
+// with a status in Retry.RetryOn, honoring Shopify's rate-limit headers
+// when present instead of blindly backing off. Every attempt's latency and
+// every failed attempt are reported to metrics, and each retry is logged, so
+// throttling and failure patterns are visible instead of scrolling logs.
+type retryTransport struct {
+	policy  RetryPolicy
+	next    http.RoundTripper
+	metrics *Metrics
+	logger  Logger
+}
+
+// HTTPClient returns an *http.Client whose transport retries failed
+// requests according to conf.Retry before giving up, so bulk uploads don't
+// fail outright the moment Shopify's leaky bucket runs dry. Every attempt
+// is recorded against DefaultMetrics, and each retry is logged via conf's
+// Logger.
+func (conf Configuration) HTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: conf.Timeout,
+		Transport: &retryTransport{
+			policy:  conf.Retry,
+			next:    http.DefaultTransport,
+			metrics: DefaultMetrics,
+			logger:  NewLogger(conf),
+		},
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attempts := t.maxAttempts()
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		start := time.Now()
+		resp, err = t.next.RoundTrip(req)
+		t.metrics.ObserveLatency(time.Since(start).Seconds())
+
+		if err == nil && !t.shouldRetry(resp.StatusCode) {
+			return resp, nil
+		}
+
+		t.metrics.IncErrors()
+		if attempt == attempts-1 {
+			break
+		}
+
+		delay := t.delay(resp, attempt)
+		t.logger.Warn("", "retrying request", map[string]interface{}{
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		})
+		if resp != nil {
+			resp.Body.Close()
+		}
+		time.Sleep(delay)
+	}
+
+	return resp, err
+}
+
+func (t *retryTransport) shouldRetry(status int) bool {
+	retryOn := t.policy.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = DefaultRetryOn
+	}
+	for _, code := range retryOn {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *retryTransport) maxAttempts() int {
+	if t.policy.MaxAttempts > 0 {
+		return t.policy.MaxAttempts
+	}
+	return DefaultRetryMaxAttempts
+}
+
+// delay computes how long to wait before the next attempt, preferring
+// Shopify's own rate-limit hints over blind exponential backoff.
+func (t *retryTransport) delay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+		if limit := resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"); limit != "" {
+			if used, max, ok := parseCallLimit(limit); ok && max > 0 && max-used <= 2 {
+				return t.backoff(attempt) * 2
+			}
+		}
+	}
+	return t.backoff(attempt)
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	initial := t.policy.InitialInterval
+	if initial <= 0 {
+		initial = DefaultRetryInitialInterval
+	}
+	maxInterval := t.policy.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultRetryMaxInterval
+	}
+	multiplier := t.policy.Multiplier
+	if multiplier <= 0 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	backoff := float64(initial) * math.Pow(multiplier, float64(attempt))
+	if backoff > float64(maxInterval) {
+		backoff = float64(maxInterval)
+	}
+
+	jitter := 1 + (rand.Float64()-0.5)*0.2 // +/-10%
+	return time.Duration(backoff * jitter)
+}
+
+// parseCallLimit parses Shopify's "X-Shopify-Shop-Api-Call-Limit" header,
+// e.g. "39/40", into its used and max values.
+func parseCallLimit(header string) (used, max int, ok bool) {
+	parts := strings.SplitN(header, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	u, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+	m, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return u, m, true
+}
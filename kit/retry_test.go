@@ -0,0 +1,55 @@
+package kit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportShouldRetry(t *testing.T) {
+	transport := &retryTransport{policy: RetryPolicy{RetryOn: []int{429, 503}}}
+
+	if !transport.shouldRetry(429) {
+		t.Error("expected 429 to be retried")
+	}
+	if transport.shouldRetry(200) {
+		t.Error("did not expect 200 to be retried")
+	}
+
+	defaultTransport := &retryTransport{}
+	if !defaultTransport.shouldRetry(500) {
+		t.Error("expected DefaultRetryOn to cover 500 when RetryOn is unset")
+	}
+}
+
+func TestRetryTransportBackoffRespectsMaxInterval(t *testing.T) {
+	transport := &retryTransport{policy: RetryPolicy{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Multiplier:      10,
+	}}
+
+	if backoff := transport.backoff(5); backoff > time.Second+time.Second/10 {
+		t.Errorf("expected backoff to be capped near MaxInterval, got %s", backoff)
+	}
+}
+
+func TestRetryTransportDelayUsesRetryAfterHeader(t *testing.T) {
+	transport := &retryTransport{}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+	if delay := transport.delay(resp, 0); delay != 2*time.Second {
+		t.Errorf("expected Retry-After to drive the delay, got %s", delay)
+	}
+}
+
+func TestParseCallLimit(t *testing.T) {
+	used, max, ok := parseCallLimit("39/40")
+	if !ok || used != 39 || max != 40 {
+		t.Errorf("expected 39/40 to parse, got used=%d max=%d ok=%v", used, max, ok)
+	}
+
+	if _, _, ok := parseCallLimit("not-a-limit"); ok {
+		t.Error("expected a malformed header to fail to parse")
+	}
+}
@@ -0,0 +1,92 @@
+package kit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes a single invalid field on a Configuration,
+// with a Code for programmatic matching and a Suggestion for how to fix it.
+type ValidationError struct {
+	Field      string
+	Code       string
+	Message    string
+	Suggestion string
+}
+
+func (err ValidationError) Error() string {
+	return err.Message
+}
+
+// Validator checks a single aspect of a Configuration, returning nil if it
+// is valid. New fields register their own Validator via RegisterValidator
+// rather than editing Validate directly.
+type Validator func(conf Configuration) *ValidationError
+
+var validators []Validator
+
+// RegisterValidator adds a Validator to the rules registry consulted by
+// Configuration.Validate and Configuration.Doctor.
+func RegisterValidator(validator Validator) {
+	validators = append(validators, validator)
+}
+
+func init() {
+	RegisterValidator(validateThemeID)
+	RegisterValidator(validateDomain)
+	RegisterValidator(validatePassword)
+}
+
+func validateThemeID(conf Configuration) *ValidationError {
+	if conf.ThemeID == "" {
+		return &ValidationError{Field: "theme_id", Code: "missing_theme_id", Message: "missing theme_id", Suggestion: "set theme_id in your config or pass --themeid"}
+	}
+	if !conf.IsLive() {
+		if _, err := strconv.ParseInt(conf.ThemeID, 10, 64); err != nil {
+			return &ValidationError{Field: "theme_id", Code: "invalid_theme_id", Message: "invalid theme_id", Suggestion: `theme_id must be numeric, or "live"`}
+		}
+	}
+	return nil
+}
+
+func validateDomain(conf Configuration) *ValidationError {
+	if len(conf.Domain) == 0 {
+		return &ValidationError{Field: "store", Code: "missing_domain", Message: "missing domain", Suggestion: "set store in your config, e.g. my-shop.myshopify.com"}
+	}
+	if !strings.HasSuffix(conf.Domain, "myshopify.com") && !strings.HasSuffix(conf.Domain, "myshopify.io") {
+		return &ValidationError{Field: "store", Code: "invalid_domain", Message: "invalid domain, must end in '.myshopify.com'", Suggestion: "double check the store domain in your config"}
+	}
+	return nil
+}
+
+func validatePassword(conf Configuration) *ValidationError {
+	// Password is only required for the default private-app password auth
+	// mode; oauth2 and jwt modes authenticate without one.
+	mode := strings.ToLower(conf.Auth.Mode)
+	if mode == "oauth2" || mode == "jwt" {
+		return nil
+	}
+
+	if conf.Password.String() == "" {
+		return &ValidationError{Field: "password", Code: "missing_password", Message: "missing password", Suggestion: "set password in your config or THEMEKIT_PASSWORD"}
+	}
+	return nil
+}
+
+// Validate runs every registered Validator against conf and joins any
+// failures into a single error, preserving the message format that
+// existing callers of Validate depend on.
+func (conf Configuration) Validate() error {
+	errors := []string{}
+	for _, validate := range validators {
+		if err := validate(conf); err != nil {
+			errors = append(errors, err.Message)
+		}
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("Invalid configuration: %v", strings.Join(errors, ","))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package kit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateThemeID(t *testing.T) {
+	if err := validateThemeID(Configuration{}); err == nil {
+		t.Error("expected a missing theme_id to fail validation")
+	}
+	if err := validateThemeID(Configuration{ThemeID: "not-a-number"}); err == nil {
+		t.Error("expected a non-numeric theme_id to fail validation")
+	}
+	if err := validateThemeID(Configuration{ThemeID: "live"}); err != nil {
+		t.Errorf("expected theme_id \"live\" to be valid, got %v", err)
+	}
+	if err := validateThemeID(Configuration{ThemeID: "123"}); err != nil {
+		t.Errorf("expected a numeric theme_id to be valid, got %v", err)
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	if err := validateDomain(Configuration{}); err == nil {
+		t.Error("expected a missing domain to fail validation")
+	}
+	if err := validateDomain(Configuration{Domain: "example.com"}); err == nil {
+		t.Error("expected a domain not ending in myshopify.com/.io to fail validation")
+	}
+	if err := validateDomain(Configuration{Domain: "shop.myshopify.com"}); err != nil {
+		t.Errorf("expected a valid myshopify.com domain to pass, got %v", err)
+	}
+	if err := validateDomain(Configuration{Domain: "shop.myshopify.io"}); err != nil {
+		t.Errorf("expected a valid myshopify.io domain to pass, got %v", err)
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	if err := validatePassword(Configuration{}); err == nil {
+		t.Error("expected a missing password to fail validation in the default auth mode")
+	}
+	if err := validatePassword(Configuration{Password: EncryptedString{Plaintext: "s3kr1t"}}); err != nil {
+		t.Errorf("expected a set password to pass validation, got %v", err)
+	}
+	if err := validatePassword(Configuration{Auth: AuthConfig{Mode: "oauth2"}}); err != nil {
+		t.Errorf("expected oauth2 mode to skip the password requirement, got %v", err)
+	}
+	if err := validatePassword(Configuration{Auth: AuthConfig{Mode: "jwt"}}); err != nil {
+		t.Errorf("expected jwt mode to skip the password requirement, got %v", err)
+	}
+}
+
+func TestValidateJoinsAllFailures(t *testing.T) {
+	err := Configuration{}.Validate()
+	if err == nil {
+		t.Fatal("expected an empty Configuration to fail validation")
+	}
+	if !strings.Contains(err.Error(), "missing theme_id") || !strings.Contains(err.Error(), "missing domain") || !strings.Contains(err.Error(), "missing password") {
+		t.Errorf("expected Validate to join every failing validator's message, got %q", err.Error())
+	}
+}
+
+func TestValidatePassesForAFullyValidConfiguration(t *testing.T) {
+	conf := Configuration{
+		ThemeID:  "123",
+		Domain:   "shop.myshopify.com",
+		Password: EncryptedString{Plaintext: "s3kr1t"},
+	}
+	if err := conf.Validate(); err != nil {
+		t.Errorf("expected a fully valid Configuration to pass, got %v", err)
+	}
+}
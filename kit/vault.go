@@ -0,0 +1,196 @@
+package kit
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	vaultTag            = "!vault"
+	vaultKeyringService = "themekit"
+	vaultKeyringUser    = "vault"
+
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+// EncryptedString is a string field that Write/Save encrypt at rest with
+// AES-256-GCM whenever a vault passphrase is available, and that the yaml
+// decoder transparently decrypts on load. Without a passphrase it is written
+// as plaintext.
+type EncryptedString struct {
+	Plaintext string
+}
+
+// String returns the decrypted value.
+func (s EncryptedString) String() string {
+	return s.Plaintext
+}
+
+// UnmarshalText lets EncryptedString be populated directly from plaintext,
+// e.g. by environment variables or command line flags.
+func (s *EncryptedString) UnmarshalText(text []byte) error {
+	s.Plaintext = string(text)
+	return nil
+}
+
+// GetYAML implements yaml.Getter so Password marshals as an encrypted
+// "!vault" block instead of plaintext whenever a vault passphrase is
+// available; otherwise it falls back to writing plaintext unchanged.
+func (s EncryptedString) GetYAML() (tag string, value interface{}) {
+	if s.Plaintext == "" {
+		return "", ""
+	}
+
+	encrypted, err := encryptVaultString(s.Plaintext)
+	if err != nil {
+		return "", s.Plaintext
+	}
+	return vaultTag, encrypted
+}
+
+// SetYAML implements yaml.Setter, decrypting "!vault" blocks and passing
+// plaintext values through unchanged so existing unencrypted configs keep working.
+func (s *EncryptedString) SetYAML(tag string, value interface{}) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	if tag != vaultTag {
+		s.Plaintext = str
+		return true
+	}
+
+	plaintext, err := decryptVaultString(str)
+	if err != nil {
+		return false
+	}
+	s.Plaintext = plaintext
+	return true
+}
+
+// EncryptValue encrypts plaintext the same way Save encrypts Password, for
+// use by a "themekit config encrypt" command.
+func EncryptValue(plaintext string) (string, error) {
+	return encryptVaultString(plaintext)
+}
+
+// DecryptValue decrypts a value produced by EncryptValue, for use by a
+// "themekit config decrypt" command.
+func DecryptValue(encoded string) (string, error) {
+	return decryptVaultString(encoded)
+}
+
+// vaultPassphrase resolves the passphrase used to derive the vault
+// encryption key, preferring THEMEKIT_VAULT_PASS and falling back to the OS keyring.
+func vaultPassphrase() (string, error) {
+	if pass := os.Getenv("THEMEKIT_VAULT_PASS"); pass != "" {
+		return pass, nil
+	}
+
+	pass, err := keyring.Get(vaultKeyringService, vaultKeyringUser)
+	if err != nil {
+		return "", fmt.Errorf("no vault passphrase available: set THEMEKIT_VAULT_PASS or store one in the OS keyring: %v", err)
+	}
+	return pass, nil
+}
+
+func deriveVaultKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+}
+
+// encryptVaultString encrypts plaintext with AES-256-GCM under a key
+// derived via scrypt, returning "<base64 salt>:<base64 nonce+ciphertext>".
+func encryptVaultString(plaintext string) (string, error) {
+	passphrase, err := vaultPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	key, err := deriveVaultKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return fmt.Sprintf("%s:%s", base64.StdEncoding.EncodeToString(salt), base64.StdEncoding.EncodeToString(ciphertext)), nil
+}
+
+// decryptVaultString reverses encryptVaultString.
+func decryptVaultString(encoded string) (string, error) {
+	passphrase, err := vaultPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.SplitN(encoded, ":", 2)
+	if len(parts) != 2 {
+		return "", errors.New("malformed vault value")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+
+	key, err := deriveVaultKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return "", errors.New("malformed vault value")
+	}
+
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,61 @@
+package kit
+
+import "testing"
+
+func TestEncryptValueRoundTrip(t *testing.T) {
+	t.Setenv("THEMEKIT_VAULT_PASS", "correct horse battery staple")
+
+	encrypted, err := EncryptValue("s3kr1t")
+	if err != nil {
+		t.Fatalf("EncryptValue returned an error: %v", err)
+	}
+	if encrypted == "s3kr1t" {
+		t.Error("expected EncryptValue to not return the plaintext unchanged")
+	}
+
+	decrypted, err := DecryptValue(encrypted)
+	if err != nil {
+		t.Fatalf("DecryptValue returned an error: %v", err)
+	}
+	if decrypted != "s3kr1t" {
+		t.Errorf("expected decrypted value to round-trip, got %q", decrypted)
+	}
+}
+
+func TestDecryptValueWrongPassphraseFails(t *testing.T) {
+	t.Setenv("THEMEKIT_VAULT_PASS", "correct horse battery staple")
+	encrypted, err := EncryptValue("s3kr1t")
+	if err != nil {
+		t.Fatalf("EncryptValue returned an error: %v", err)
+	}
+
+	t.Setenv("THEMEKIT_VAULT_PASS", "wrong passphrase")
+	if _, err := DecryptValue(encrypted); err == nil {
+		t.Error("expected decrypting with the wrong passphrase to fail")
+	}
+}
+
+func TestDecryptValueMalformed(t *testing.T) {
+	t.Setenv("THEMEKIT_VAULT_PASS", "correct horse battery staple")
+	if _, err := DecryptValue("not-a-vault-value"); err == nil {
+		t.Error("expected a malformed vault value to fail to decrypt")
+	}
+}
+
+func TestEncryptedStringYAMLRoundTrip(t *testing.T) {
+	t.Setenv("THEMEKIT_VAULT_PASS", "correct horse battery staple")
+
+	original := EncryptedString{Plaintext: "s3kr1t"}
+	tag, value := original.GetYAML()
+	if tag != vaultTag {
+		t.Fatalf("expected GetYAML to tag the value as %q, got %q", vaultTag, tag)
+	}
+
+	var roundTripped EncryptedString
+	if ok := roundTripped.SetYAML(tag, value); !ok {
+		t.Fatal("expected SetYAML to accept the encrypted value")
+	}
+	if roundTripped.Plaintext != "s3kr1t" {
+		t.Errorf("expected SetYAML to decrypt back to the original plaintext, got %q", roundTripped.Plaintext)
+	}
+}